@@ -1,20 +1,26 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MagnetonIO/go-test/pkg/telemetry"
 )
 
 func TestLTPEndpoint(t *testing.T) {
 	// Initialize cache with some test data
 	priceCache.Lock()
 	priceCache.data = map[string]LTPData{
-		"BTC/USD": {Pair: "BTC/USD", Amount: 52000.12},
-		"BTC/EUR": {Pair: "BTC/EUR", Amount: 50000.12},
-		"BTC/CHF": {Pair: "BTC/CHF", Amount: 49000.12},
+		"BTC/USD": {Pair: "BTC/USD", Amount: decimal.NewFromFloat(52000.12)},
+		"BTC/EUR": {Pair: "BTC/EUR", Amount: decimal.NewFromFloat(50000.12)},
+		"BTC/CHF": {Pair: "BTC/CHF", Amount: decimal.NewFromFloat(49000.12)},
 	}
 	priceCache.lastUpdated = time.Now()
 	priceCache.Unlock()
@@ -99,14 +105,54 @@ func TestLTPEndpoint(t *testing.T) {
 	}
 }
 
+// TestStreamEndpointFlushesThroughInstrument exercises /api/v1/stream
+// wrapped in telemetry.Instrument, the way main actually registers it - a
+// bare call to handleStream wouldn't have caught the flusher-wrapping
+// regression this guards against.
+func TestStreamEndpointFlushesThroughInstrument(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stream", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	handler := telemetry.Instrument(metrics, "stream", handleStream)
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	// Give handleStream time to subscribe before we publish.
+	time.Sleep(20 * time.Millisecond)
+	hub.publish(LTPData{Pair: "BTC/USD", Amount: decimal.NewFromFloat(50000)})
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body: %q)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"pair":"BTC/USD"`) {
+		t.Errorf("expected the published tick in the SSE body, got %q", rec.Body.String())
+	}
+}
+
 func TestKrakenIntegration(t *testing.T) {
 	// Skip this test in CI environments or add a flag to enable/disable
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
 	}
 
-	// Update cache from Kraken
-	updatePriceCache()
+	// Update cache from all configured exchanges
+	registeredPairs := pairRegistry.All()
+	pairs := make([]string, 0, len(registeredPairs))
+	for _, p := range registeredPairs {
+		pairs = append(pairs, p.Display)
+	}
+	updatePriceCache(pairs)
 
 	// Check if cache was updated
 	priceCache.RLock()
@@ -117,7 +163,7 @@ func TestKrakenIntegration(t *testing.T) {
 	}
 
 	// Check if we have data for all pairs
-	for pair := range supportedPairs {
+	for _, pair := range pairs {
 		if _, ok := priceCache.data[pair]; !ok {
 			t.Errorf("Expected data for pair %s not found in cache", pair)
 		}
@@ -125,8 +171,8 @@ func TestKrakenIntegration(t *testing.T) {
 
 	// Check if amounts are reasonable (BTC should be worth something)
 	for pair, data := range priceCache.data {
-		if data.Amount <= 0 {
-			t.Errorf("Expected positive amount for pair %s, got %f", pair, data.Amount)
+		if !data.Amount.IsPositive() {
+			t.Errorf("Expected positive amount for pair %s, got %s", pair, data.Amount)
 		}
 	}
 }
@@ -1,50 +1,142 @@
 package main
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
-	"log"
-	"math/rand"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/MagnetonIO/go-test/pkg/candles"
+	"github.com/MagnetonIO/go-test/pkg/exchanges"
+	"github.com/MagnetonIO/go-test/pkg/registry"
+	"github.com/MagnetonIO/go-test/pkg/stream"
+	"github.com/MagnetonIO/go-test/pkg/telemetry"
 )
 
-// Pair represents a trading pair
-type Pair struct {
-	Base    string
-	Quote   string
-	Display string
-}
+// wsStaleness is how long a pair may go without a websocket tick before the
+// REST reconciliation fallback treats it as stale and refetches it.
+const wsStaleness = 45 * time.Second
+
+// tickRetention is how long raw ticks are kept in the candle store before
+// being pruned; the candle buckets derived from them are kept indefinitely.
+const tickRetention = 7 * 24 * time.Hour
+
+// pairConfigFile is where the registry's initial set of supported pairs is
+// seeded from; POST /api/v1/pairs admits pairs beyond this set at runtime.
+const pairConfigFile = "config/pairs.yaml"
+
+// adminTokenEnv names the environment variable holding the bearer token
+// required to mutate the pair registry. Admin endpoints reject every
+// request if it's unset.
+const adminTokenEnv = "ADMIN_TOKEN"
 
 // LTPResponse represents the response structure
 type LTPResponse struct {
 	LTP []LTPData `json:"ltp"`
 }
 
-// LTPData represents the price data for a pair
+// LTPData represents the price data for a pair. Amount and Sources are
+// shopspring/decimal values so traders don't lose sub-cent precision on
+// pairs like ETH/BTC; they marshal as JSON strings by default. Pass
+// ?format=float on /api/v1/ltp for plain numeric JSON instead.
 type LTPData struct {
-	Pair   string  `json:"pair"`
-	Amount float64 `json:"amount"`
+	Pair   string          `json:"pair"`
+	Amount decimal.Decimal `json:"amount"`
+	// Sources holds each responding exchange's last price from the most
+	// recent REST reconciliation pass, keyed by exchange name, including
+	// prices discarded as outliers before the VWAP was computed. A websocket
+	// tick replaces Amount with Kraken's price and leaves Sources unchanged.
+	Sources map[string]decimal.Decimal `json:"sources,omitempty"`
+	// UpdatedAt is when this pair last received a price, whether from the
+	// websocket stream or a REST reconciliation pass.
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// KrakenTickerResponse represents the response from Kraken API
-type KrakenTickerResponse struct {
-	Error  []string               `json:"error"`
-	Result map[string]KrakenPair `json:"result"`
+// logger is the service's structured JSON logger; every log line a request
+// touches is annotated with its correlation ID via telemetry.WithRequest.
+var logger = telemetry.NewLogger()
+
+// metrics holds every Prometheus collector exported on /metrics.
+var metrics = telemetry.NewMetrics()
+
+// krakenClient is shared between the aggregator and pairRegistry, which
+// needs Kraken specifically since it's the only venue we validate new pairs
+// and fetch tick sizes from.
+var krakenClient = exchanges.NewKraken()
+
+// pairRegistry holds the set of supported pairs. It's seeded from
+// pairConfigFile at startup and mutable at runtime via /api/v1/pairs.
+var pairRegistry = registry.NewRegistry(krakenClient)
+
+// krakenStream is set up in main once pairRegistry has been seeded; the
+// registry's OnAdd hook uses it to subscribe newly admitted pairs on the
+// live connection, and DELETE /api/v1/pairs/{name} to unsubscribe removed
+// ones.
+var krakenStream *stream.KrakenStream
+
+func init() {
+	// Loaded eagerly (rather than in main) so package-level state is ready
+	// for tests too, without requiring every test to dial out to Kraken for
+	// tick sizes - that part stays in main via RefreshTickSizes.
+	if err := pairRegistry.LoadFile(pairConfigFile); err != nil {
+		logger.Fatal("failed to load pair config", zap.Error(err))
+	}
+}
+
+// aggregator fans out to every configured venue and computes the VWAP
+// consensus price for each pair. Outliers more than 2 standard deviations
+// from the median are discarded before averaging.
+var aggregator = exchanges.NewPriceAggregator([]exchanges.ExchangeClient{
+	krakenClient,
+	exchanges.NewBinance(),
+	exchanges.NewCoinbase(),
+	exchanges.NewBitfinex(),
+	exchanges.NewKucoin(),
+}, 2, metrics)
+
+// tickSizeFor looks up a pair's price tick size in the registry, returning
+// the zero decimal (no rounding) if the pair is unknown or not yet resolved.
+func tickSizeFor(display string) decimal.Decimal {
+	p, ok := pairRegistry.Get(display)
+	if !ok {
+		return decimal.Decimal{}
+	}
+	return p.PriceTickSize
 }
 
-// KrakenPair contains ticker information for a pair
-type KrakenPair struct {
-	C []string `json:"c"` // c = last trade closed (price, lot volume)
+// onPairAdded is fired by pairRegistry whenever Add admits a new pair via
+// POST /api/v1/pairs; pairs loaded from pairConfigFile at startup don't fire
+// it. It subscribes the pair on the live websocket stream and starts a REST
+// refresh for just that pair rather than waiting on the next reconciliation
+// pass; if another refresh is already running, updatePriceCache skips it.
+func onPairAdded(p registry.Pair) {
+	if krakenStream != nil {
+		if err := krakenStream.AddPair(p.Display, p.Base+"/"+p.Quote); err != nil {
+			logger.Error("failed to subscribe pair on stream", zap.String("pair", p.Display), zap.Error(err))
+		}
+	}
+	go updatePriceCache([]string{p.Display})
 }
 
-// Supported pairs
-var supportedPairs = map[string]Pair{
-	"BTC/USD": {Base: "XBT", Quote: "USD", Display: "BTC/USD"},
-	"BTC/CHF": {Base: "XBT", Quote: "CHF", Display: "BTC/CHF"},
-	"BTC/EUR": {Base: "XBT", Quote: "EUR", Display: "BTC/EUR"},
+// roundToTick rounds amount down to the nearest multiple of tick, so a
+// displayed price never implies more precision than the venue actually
+// quotes. A zero tick (not yet loaded) leaves amount unrounded.
+func roundToTick(amount decimal.Decimal, tick decimal.Decimal) decimal.Decimal {
+	if tick.IsZero() {
+		return amount
+	}
+	return amount.Div(tick).RoundFloor(0).Mul(tick)
 }
 
 // Cache for last traded prices
@@ -57,25 +149,104 @@ var priceCache = struct {
 	data: make(map[string]LTPData),
 }
 
+// hub fans out every price tick, however it was sourced, to /api/v1/stream
+// subscribers.
+var hub = newSSEHub()
+
+// candleStore persists every tick into OHLCV buckets. It stays off the LTP
+// hot path entirely; only /api/v1/ohlc reads from it.
+var candleStore candles.CandleStore
+
 func main() {
-	// Initialize and do first cache update
-	updatePriceCache()
+	defer logger.Sync()
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background())
+	if err != nil {
+		logger.Fatal("failed to init tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to shut down tracing", zap.Error(err))
+		}
+	}()
+
+	store, err := candles.NewSQLiteStore("prices.db", tickRetention)
+	if err != nil {
+		logger.Fatal("failed to open candle store", zap.Error(err))
+	}
+	candleStore = store
+	defer candleStore.Close()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		for range ticker.C {
+			if err := candleStore.Compact(context.Background()); err != nil {
+				logger.Error("candle compaction failed", zap.Error(err))
+			}
+		}
+	}()
+
+	if err := pairRegistry.RefreshTickSizes(context.Background()); err != nil {
+		logger.Warn("failed to load tick sizes, amounts will be unrounded", zap.Error(err))
+	}
+	pairRegistry.OnAdd(onPairAdded)
+
+	wsPairs := make(map[string]string)
+	for _, p := range pairRegistry.All() {
+		wsPairs[p.Display] = p.Base + "/" + p.Quote
+	}
+
+	// The websocket feed is the primary source of truth; REST only steps
+	// in per pair once the feed has gone quiet for longer than wsStaleness.
+	krakenStream = stream.NewKrakenStream(wsPairs, handleStreamTick, logger)
+	go krakenStream.Run(context.Background())
+
+	// Seed the cache before serving traffic in case the WS handshake is
+	// still in flight.
+	reconcileStalePairs()
 
-	// Start periodic updates every 30 seconds
 	go func() {
-		ticker := time.NewTicker(30 * time.Second)
+		ticker := time.NewTicker(10 * time.Second)
 		for range ticker.C {
-			updatePriceCache()
+			reconcileStalePairs()
 		}
 	}()
 
-	// Setup API routes
-	http.HandleFunc("/api/v1/ltp", handleLTP)
+	// Setup API routes. Each is wrapped in telemetry.Instrument to record
+	// handler latency and status-code distribution on /metrics.
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/api/v1/ltp", telemetry.Instrument(metrics, "ltp", handleLTP))
+	mux.Handle("/api/v1/stream", telemetry.Instrument(metrics, "stream", handleStream))
+	mux.Handle("/api/v1/ohlc", telemetry.Instrument(metrics, "ohlc", handleOHLC))
+	mux.Handle("/api/v1/pairs", telemetry.Instrument(metrics, "pairs", handlePairsCollection))
+	mux.Handle("/api/v1/pairs/", telemetry.Instrument(metrics, "pairs_by_name", handlePairByName))
 
 	// Start server
 	port := ":8080"
-	fmt.Printf("Starting server on port %s\n", port)
-	log.Fatal(http.ListenAndServe(port, nil))
+	logger.Info("starting server", zap.String("port", port))
+	logger.Fatal("server exited", zap.Error(http.ListenAndServe(port, telemetry.RequestIDMiddleware(mux))))
+}
+
+// handleStreamTick is invoked for every tick the Kraken websocket feed
+// delivers, keeping the cache current without waiting on REST polling.
+func handleStreamTick(u stream.Update) {
+	amount := roundToTick(decimal.NewFromFloat(u.Price), tickSizeFor(u.Pair))
+
+	priceCache.Lock()
+	data := priceCache.data[u.Pair]
+	data.Pair = u.Pair
+	data.Amount = amount
+	data.UpdatedAt = u.Time
+	priceCache.data[u.Pair] = data
+	priceCache.lastUpdated = u.Time
+	priceCache.Unlock()
+
+	hub.publish(data)
+
+	if err := candleStore.Ingest(context.Background(), u.Pair, amount.InexactFloat64(), u.Volume, u.Time); err != nil {
+		logger.Error("failed to ingest tick", zap.String("pair", u.Pair), zap.Error(err))
+	}
 }
 
 // handleLTP handles the LTP API endpoint
@@ -90,44 +261,151 @@ func handleLTP(w http.ResponseWriter, r *http.Request) {
 
 	// If no pairs specified, return all supported pairs
 	if len(requestedPairs) == 0 {
-		requestedPairs = make([]string, 0, len(supportedPairs))
-		for pair := range supportedPairs {
-			requestedPairs = append(requestedPairs, pair)
+		for _, p := range pairRegistry.All() {
+			requestedPairs = append(requestedPairs, p.Display)
 		}
 	}
 
 	response := LTPResponse{LTP: []LTPData{}}
+	var stale bool
 	priceCache.RLock()
 
-	// Check if cache needs updating (older than 1 minute)
-	needsUpdate := time.Since(priceCache.lastUpdated) > time.Minute
-
 	// Add requested pairs to response
 	for _, pairName := range requestedPairs {
 		pairName = strings.ToUpper(pairName)
-		if _, exists := supportedPairs[pairName]; !exists {
+		if _, exists := pairRegistry.Get(pairName); !exists {
 			continue // Skip unsupported pairs
 		}
+		metrics.RequestsPerPair.WithLabelValues(pairName).Inc()
 
-		if data, found := priceCache.data[pairName]; found {
-			response.LTP = append(response.LTP, data)
+		data, found := priceCache.data[pairName]
+		if !found {
+			metrics.CacheMisses.Inc()
+			continue
 		}
+		metrics.CacheHits.Inc()
+		if time.Since(data.UpdatedAt) > wsStaleness {
+			stale = true
+		}
+		response.LTP = append(response.LTP, data)
 	}
 	priceCache.RUnlock()
 
-	// Update cache if needed
-	if needsUpdate && !priceCache.updateRunning {
-		go updatePriceCache()
+	if stale {
+		metrics.CacheStale.Inc()
 	}
 
-	// Return response
+	// Top up any pair the websocket feed has gone quiet on.
+	go reconcileStalePairs()
+
+	telemetry.WithRequest(logger, r.Context()).Debug("served ltp request", zap.Int("pairs", len(response.LTP)))
+
 	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Query().Get("format") == "float" {
+		json.NewEncoder(w).Encode(toFloatResponse(response))
+		return
+	}
 	json.NewEncoder(w).Encode(response)
 }
 
-// updatePriceCache fetches latest prices from Kraken and updates cache
-func updatePriceCache() {
+// floatLTPResponse is the backward-compatible numeric-JSON shape for
+// clients written against the old float64 Amount/Sources fields.
+type floatLTPResponse struct {
+	LTP []floatLTPData `json:"ltp"`
+}
+
+type floatLTPData struct {
+	Pair      string             `json:"pair"`
+	Amount    float64            `json:"amount"`
+	Sources   map[string]float64 `json:"sources,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func toFloatResponse(r LTPResponse) floatLTPResponse {
+	out := floatLTPResponse{LTP: make([]floatLTPData, len(r.LTP))}
+	for i, d := range r.LTP {
+		var sources map[string]float64
+		if d.Sources != nil {
+			sources = make(map[string]float64, len(d.Sources))
+			for exchange, price := range d.Sources {
+				sources[exchange] = price.InexactFloat64()
+			}
+		}
+		out.LTP[i] = floatLTPData{
+			Pair:      d.Pair,
+			Amount:    d.Amount.InexactFloat64(),
+			Sources:   sources,
+			UpdatedAt: d.UpdatedAt,
+		}
+	}
+	return out
+}
+
+// handleStream relays price ticks to a browser client over Server-Sent
+// Events as they arrive, so consumers don't need to poll /api/v1/ltp.
+func handleStream(w http.ResponseWriter, r *http.Request) {
+	// Instrument wraps w in a statusRecorder that only promotes the
+	// http.ResponseWriter interface, so a w.(http.Flusher) assertion here
+	// would always fail; NewResponseController looks past wrapper types
+	// for an underlying Flush instead.
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	updates := hub.subscribe()
+	defer hub.unsubscribe(updates)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-updates:
+			payload, err := json.Marshal(data)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// reconcileStalePairs refreshes, via REST, any pair the websocket feed
+// hasn't ticked in over wsStaleness - or has never ticked at all, which
+// also covers the case where the WS handshake itself failed.
+func reconcileStalePairs() {
+	var stale []string
+	for _, p := range pairRegistry.All() {
+		var lastTick time.Time
+		var ok bool
+		if krakenStream != nil {
+			lastTick, ok = krakenStream.LastUpdated(p.Display)
+		}
+		if !ok || time.Since(lastTick) > wsStaleness {
+			stale = append(stale, p.Display)
+		}
+	}
+
+	if len(stale) > 0 {
+		updatePriceCache(stale)
+	}
+}
+
+// updatePriceCache fans out to every configured exchange in parallel and
+// stores the VWAP consensus price for each of the given pairs. It is the
+// REST reconciliation/fallback path; the websocket stream is primary.
+func updatePriceCache(pairs []string) {
+	ctx, span := telemetry.Tracer.Start(context.Background(), "cache.fallbackRefresh",
+		trace.WithAttributes(attribute.Int("pairs", len(pairs))))
+	defer span.End()
+
+	_, lockSpan := telemetry.Tracer.Start(ctx, "cache.lock.check")
 	priceCache.Lock()
+	lockSpan.End()
 	if priceCache.updateRunning {
 		priceCache.Unlock()
 		return
@@ -141,193 +419,194 @@ func updatePriceCache() {
 		priceCache.Unlock()
 	}()
 
-	// Build comma-separated list of Kraken pairs
-	var krakenPairs []string
-	for _, pair := range supportedPairs {
-		// Kraken uses XBT instead of BTC
-		krakenPairs = append(krakenPairs, fmt.Sprintf("%s%s", pair.Base, pair.Quote))
-	}
-
-	// Configure HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-		Transport: &http.Transport{
-			MaxIdleConns:        10,
-			MaxConnsPerHost:     5,
-			MaxIdleConnsPerHost: 5,
-			IdleConnTimeout:     30 * time.Second,
-			DisableKeepAlives:   false, // Enable keep-alive to reuse connections
-		},
-	}
-
-	// Retry logic configuration
-	maxRetries := 3
-	var resp *http.Response
-	var err error
-	var krakenResponse KrakenTickerResponse
-
-	// Try individual requests for each pair if combined request fails
-	apiURL := fmt.Sprintf("https://api.kraken.com/0/public/Ticker?pair=%s", strings.Join(krakenPairs, ","))
-
-	// Try with exponential backoff
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff with jitter
-			backoffTime := time.Duration(100*(1<<attempt)) * time.Millisecond
-			jitter := time.Duration(rand.Intn(100)) * time.Millisecond
-			time.Sleep(backoffTime + jitter)
-			log.Printf("Retrying Kraken API request (attempt %d/%d)", attempt+1, maxRetries)
-		}
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
 
-		// Make request to Kraken API
-		resp, err = client.Get(apiURL)
-		if err == nil {
-			break
-		}
-		log.Printf("Error fetching from Kraken (attempt %d/%d): %v", attempt+1, maxRetries, err)
+	consensus := aggregator.Refresh(ctx, pairs)
+	if len(consensus) == 0 {
+		logger.Warn("price refresh failed: no exchange returned data for any pair")
+		return
 	}
 
-	// If all retries failed, try individual requests for each pair
-	if err != nil {
-		log.Printf("All combined requests failed, trying individual pairs...")
-
-		// Try each pair individually
-		for _, pairInfo := range supportedPairs {
-			krakenPair := fmt.Sprintf("%s%s", pairInfo.Base, pairInfo.Quote)
-			singlePairURL := fmt.Sprintf("https://api.kraken.com/0/public/Ticker?pair=%s", krakenPair)
-
-			// Try with exponential backoff for individual pair
-			var singleResp *http.Response
-			var singleErr error
-
-			for attempt := 0; attempt < maxRetries; attempt++ {
-				if attempt > 0 {
-					backoffTime := time.Duration(100*(1<<attempt)) * time.Millisecond
-					jitter := time.Duration(rand.Intn(100)) * time.Millisecond
-					time.Sleep(backoffTime + jitter)
-				}
-
-				singleResp, singleErr = client.Get(singlePairURL)
-				if singleErr == nil {
-					break
-				}
-			}
-
-			if singleErr != nil {
-				log.Printf("Failed to fetch data for %s: %v", pairInfo.Display, singleErr)
-				continue
-			}
-
-			var singlePairResponse KrakenTickerResponse
-			if err := json.NewDecoder(singleResp.Body).Decode(&singlePairResponse); err != nil {
-				log.Printf("Error decoding response for %s: %v", pairInfo.Display, err)
-				singleResp.Body.Close()
-				continue
-			}
-			singleResp.Body.Close()
+	now := time.Now()
+	updated := make([]LTPData, 0, len(consensus))
+	_, mergeSpan := telemetry.Tracer.Start(ctx, "cache.lock.merge")
+	priceCache.Lock()
+	for pair, c := range consensus {
+		tick := tickSizeFor(pair)
+		sources := make(map[string]decimal.Decimal, len(c.Sources))
+		for exchange, price := range c.Sources {
+			sources[exchange] = roundToTick(price, tick)
+		}
 
-			if len(singlePairResponse.Error) > 0 {
-				log.Printf("Kraken API error for %s: %v", pairInfo.Display, singlePairResponse.Error)
-				continue
-			}
+		data := LTPData{Pair: pair, Amount: roundToTick(c.VWAP, tick), Sources: sources, UpdatedAt: now}
+		priceCache.data[pair] = data
+		updated = append(updated, data)
+	}
+	priceCache.lastUpdated = now
+	priceCache.Unlock()
+	mergeSpan.End()
 
-			// Process this individual pair response
-			processPairResponse(pairInfo, singlePairResponse)
+	for _, data := range updated {
+		hub.publish(data)
+		// candleStore is only opened in main; tests drive this path without one.
+		if candleStore == nil {
+			continue
 		}
+		if err := candleStore.Ingest(ctx, data.Pair, data.Amount.InexactFloat64(), consensus[data.Pair].Volume.InexactFloat64(), now); err != nil {
+			logger.Error("failed to ingest reconciled tick", zap.String("pair", data.Pair), zap.Error(err))
+		}
+	}
+}
 
-		// Update lastUpdated time even for partial updates
-		priceCache.Lock()
-		priceCache.lastUpdated = time.Now()
-		priceCache.Unlock()
+// handleOHLC serves historical candles from the candle store, computed on
+// the fly at ingest time and rolled up by the background compaction task.
+func handleOHLC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
+	pair := strings.ToUpper(r.URL.Query().Get("pair"))
+	if _, ok := pairRegistry.Get(pair); !ok {
+		http.Error(w, "Unknown or missing pair", http.StatusBadRequest)
 		return
 	}
 
-	defer resp.Body.Close()
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "1m"
+	}
+	if !validInterval(interval) {
+		http.Error(w, "Unsupported interval", http.StatusBadRequest)
+		return
+	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&krakenResponse); err != nil {
-		log.Printf("Error decoding Kraken response: %v", err)
+	from, err := parseTimeParam(r.URL.Query().Get("from"), time.Now().Add(-24*time.Hour))
+	if err != nil {
+		http.Error(w, "Invalid from", http.StatusBadRequest)
+		return
+	}
+	to, err := parseTimeParam(r.URL.Query().Get("to"), time.Now())
+	if err != nil {
+		http.Error(w, "Invalid to", http.StatusBadRequest)
 		return
 	}
 
-	if len(krakenResponse.Error) > 0 {
-		log.Printf("Kraken API error: %v", krakenResponse.Error)
+	rows, err := candleStore.Query(r.Context(), pair, interval, from, to)
+	if err != nil {
+		logger.Error("ohlc query failed", zap.String("pair", pair), zap.String("interval", interval), zap.Error(err))
+		http.Error(w, "Internal error", http.StatusInternalServerError)
 		return
 	}
 
-	// Process response and update cache
-	processPairResponse(Pair{}, krakenResponse)
+	out := make([][6]float64, len(rows))
+	for i, c := range rows {
+		out[i] = [6]float64{float64(c.Timestamp.Unix()), c.Open, c.High, c.Low, c.Close, c.Volume}
+	}
 
-	priceCache.Lock()
-	priceCache.lastUpdated = time.Now()
-	priceCache.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
 }
 
-// processPairResponse handles processing of Kraken API responses and updates the price cache
-func processPairResponse(singlePair Pair, krakenResponse KrakenTickerResponse) {
-	priceCache.Lock()
-	defer priceCache.Unlock()
-
-	// If we're processing a single pair
-	if singlePair.Display != "" {
-		krakenPair := fmt.Sprintf("%s%s", singlePair.Base, singlePair.Quote)
-		// For some pairs, Kraken might add "X" or "Z" prefixes
-		altKrakenPair := fmt.Sprintf("X%sZ%s", singlePair.Base, singlePair.Quote)
-
-		var ticker KrakenPair
-		var found bool
-
-		// Try both formats
-		if t, ok := krakenResponse.Result[krakenPair]; ok {
-			ticker = t
-			found = true
-		} else if t, ok := krakenResponse.Result[altKrakenPair]; ok {
-			ticker = t
-			found = true
+func validInterval(interval string) bool {
+	for _, i := range candles.Intervals {
+		if i.Name == interval {
+			return true
 		}
+	}
+	return false
+}
 
-		if found && len(ticker.C) >= 1 {
-			// Extract and convert price to float
-			var price float64
-			fmt.Sscanf(ticker.C[0], "%f", &price)
+// parseTimeParam parses a Unix-seconds query parameter, falling back to def
+// when raw is empty.
+func parseTimeParam(raw string, def time.Time) (time.Time, error) {
+	if raw == "" {
+		return def, nil
+	}
+	var secs int64
+	if _, err := fmt.Sscanf(raw, "%d", &secs); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(secs, 0), nil
+}
 
-			priceCache.data[singlePair.Display] = LTPData{
-				Pair:   singlePair.Display,
-				Amount: price,
-			}
+// isAuthorized reports whether r carries the bearer token configured via
+// ADMIN_TOKEN. Every mutating /api/v1/pairs request is rejected if the
+// environment variable isn't set.
+func isAuthorized(r *http.Request) bool {
+	token := os.Getenv(adminTokenEnv)
+	if token == "" {
+		return false
+	}
+	got := r.Header.Get("Authorization")
+	want := "Bearer " + token
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+// handlePairsCollection serves GET (list supported pairs) and POST (admit a
+// new one) on /api/v1/pairs.
+func handlePairsCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pairRegistry.All())
+
+	case http.MethodPost:
+		if !isAuthorized(r) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
 		}
 
-		return
-	}
+		var body struct {
+			Pair string `json:"pair"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
 
-	// Process all pairs from a combined response
-	for displayPair, pairInfo := range supportedPairs {
-		krakenPair := fmt.Sprintf("%s%s", pairInfo.Base, pairInfo.Quote)
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+		pair, err := pairRegistry.Add(ctx, body.Pair)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		// For some pairs, Kraken might add "X" or "Z" prefixes
-		altKrakenPair := fmt.Sprintf("X%sZ%s", pairInfo.Base, pairInfo.Quote)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(pair)
 
-		var ticker KrakenPair
-		var found bool
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
 
-		// Try both formats
-		if t, ok := krakenResponse.Result[krakenPair]; ok {
-			ticker = t
-			found = true
-		} else if t, ok := krakenResponse.Result[altKrakenPair]; ok {
-			ticker = t
-			found = true
-		}
+// handlePairByName serves DELETE /api/v1/pairs/{name}, dropping a pair from
+// the registry and unsubscribing it from the live websocket stream.
+func handlePairByName(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isAuthorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
-		if found && len(ticker.C) >= 1 {
-			// Extract and convert price to float
-			var price float64
-			fmt.Sscanf(ticker.C[0], "%f", &price)
+	display := strings.TrimPrefix(r.URL.Path, "/api/v1/pairs/")
+	pair, ok := pairRegistry.Remove(display)
+	if !ok {
+		http.Error(w, "Pair not found", http.StatusNotFound)
+		return
+	}
 
-			priceCache.data[displayPair] = LTPData{
-				Pair:   displayPair,
-				Amount: price,
-			}
+	if krakenStream != nil {
+		if err := krakenStream.RemovePair(pair.Display, pair.Base+"/"+pair.Quote); err != nil {
+			logger.Error("failed to unsubscribe pair from stream", zap.String("pair", pair.Display), zap.Error(err))
 		}
 	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,49 @@
+// Package candles provides a pluggable historical OHLCV store. The live
+// price cache in main.go remains the hot path for LTP reads; this package
+// is only consulted for /api/v1/ohlc queries.
+package candles
+
+import (
+	"context"
+	"time"
+)
+
+// Candle is one OHLCV bucket.
+type Candle struct {
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// Intervals are the candle widths this package maintains, from finest to
+// coarsest. Compact rolls each interval up into the next.
+var Intervals = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"1h", time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// CandleStore ingests ticks into candle buckets and serves OHLC queries. The
+// in-memory price cache stays the hot path for LTP reads; CandleStore only
+// backs the historical /api/v1/ohlc endpoint.
+type CandleStore interface {
+	// Ingest records one price tick, updating the finest (1m) candle
+	// bucket it falls into.
+	Ingest(ctx context.Context, pair string, price, volume float64, at time.Time) error
+	// Query returns the candles for pair at the given interval (one of
+	// Intervals' Name values) whose bucket falls within [from, to].
+	Query(ctx context.Context, pair, interval string, from, to time.Time) ([]Candle, error)
+	// Compact rolls finer buckets up into coarser ones and prunes raw
+	// ticks past the store's retention window. Intended to run on a
+	// background timer.
+	Compact(ctx context.Context) error
+	// Close releases the store's underlying resources.
+	Close() error
+}
@@ -0,0 +1,234 @@
+package candles
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS ticks (
+	pair   TEXT NOT NULL,
+	price  REAL NOT NULL,
+	volume REAL NOT NULL,
+	ts     INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ticks_pair_ts ON ticks(pair, ts);
+
+CREATE TABLE IF NOT EXISTS candles (
+	interval     TEXT NOT NULL,
+	pair         TEXT NOT NULL,
+	bucket_start INTEGER NOT NULL,
+	open         REAL NOT NULL,
+	high         REAL NOT NULL,
+	low          REAL NOT NULL,
+	close        REAL NOT NULL,
+	volume       REAL NOT NULL,
+	PRIMARY KEY (interval, pair, bucket_start)
+);
+`
+
+// SQLiteStore is a CandleStore backed by modernc.org/sqlite, a pure-Go
+// sqlite driver that needs no cgo.
+type SQLiteStore struct {
+	db        *sql.DB
+	retention time.Duration
+
+	// rollupMark is the highest fromInterval bucket_start already folded
+	// into the next coarser interval, keyed by fromInterval. Completed
+	// buckets never change once folded, so rollup only needs to re-scan
+	// buckets newer than this on each Compact pass instead of the table's
+	// entire history.
+	rollupMark map[string]int64
+}
+
+// NewSQLiteStore opens (creating if necessary) a sqlite database at path
+// and ensures its schema exists. retention is how long raw ticks are kept
+// before Compact prunes them; candle buckets themselves are never pruned.
+func NewSQLiteStore(path string, retention time.Duration) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate schema: %w", err)
+	}
+	return &SQLiteStore{db: db, retention: retention, rollupMark: make(map[string]int64)}, nil
+}
+
+func (s *SQLiteStore) Close() error { return s.db.Close() }
+
+// Ingest records the tick and folds it into the current 1m candle for pair.
+// Coarser intervals are only produced by Compact's rollup.
+func (s *SQLiteStore) Ingest(ctx context.Context, pair string, price, volume float64, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO ticks (pair, price, volume, ts) VALUES (?, ?, ?, ?)`,
+		pair, price, volume, at.Unix(),
+	); err != nil {
+		return fmt.Errorf("insert tick: %w", err)
+	}
+
+	bucketStart := at.Truncate(time.Minute).Unix()
+	return s.foldTick(ctx, "1m", pair, bucketStart, price, volume)
+}
+
+// foldTick upserts a single tick into an existing candle bucket, widening
+// high/low, replacing close, and accumulating volume.
+func (s *SQLiteStore) foldTick(ctx context.Context, interval, pair string, bucketStart int64, price, volume float64) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO candles (interval, pair, bucket_start, open, high, low, close, volume)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(interval, pair, bucket_start) DO UPDATE SET
+			high   = MAX(high, excluded.high),
+			low    = MIN(low, excluded.low),
+			close  = excluded.close,
+			volume = volume + excluded.volume
+	`, interval, pair, bucketStart, price, price, price, price, volume)
+	if err != nil {
+		return fmt.Errorf("fold tick into %s candle: %w", interval, err)
+	}
+	return nil
+}
+
+// Query returns candles for pair at interval whose bucket falls in [from, to].
+func (s *SQLiteStore) Query(ctx context.Context, pair, interval string, from, to time.Time) ([]Candle, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT bucket_start, open, high, low, close, volume
+		FROM candles
+		WHERE interval = ? AND pair = ? AND bucket_start BETWEEN ? AND ?
+		ORDER BY bucket_start
+	`, interval, pair, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query candles: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Candle
+	for rows.Next() {
+		var bucketStart int64
+		var c Candle
+		if err := rows.Scan(&bucketStart, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume); err != nil {
+			return nil, fmt.Errorf("scan candle: %w", err)
+		}
+		c.Timestamp = time.Unix(bucketStart, 0).UTC()
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Compact rolls each interval up into the next coarser one and prunes raw
+// ticks past the retention window. Each rollup only folds in buckets it
+// hasn't seen before, so repeated calls accumulate correctly rather than
+// double-counting or re-scanning history already rolled up.
+func (s *SQLiteStore) Compact(ctx context.Context) error {
+	for i := 0; i < len(Intervals)-1; i++ {
+		from, to := Intervals[i], Intervals[i+1]
+		if err := s.rollup(ctx, from.Name, to.Name, to.Duration); err != nil {
+			return fmt.Errorf("rollup %s -> %s: %w", from.Name, to.Name, err)
+		}
+	}
+	return s.pruneTicks(ctx)
+}
+
+// rollup aggregates completed finer-interval buckets into toInterval
+// buckets of width toDuration, merging into any existing coarser candle.
+// It only rescans fromInterval buckets newer than the high-water mark left
+// by the previous call, since a bucket that's already been folded is never
+// touched again - without that, every Compact tick would re-aggregate the
+// store's entire history, growing unboundedly with its age and size.
+func (s *SQLiteStore) rollup(ctx context.Context, fromInterval, toInterval string, toDuration time.Duration) error {
+	mark := s.rollupMark[fromInterval]
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pair, bucket_start, open, high, low, close, volume
+		FROM candles WHERE interval = ? AND bucket_start > ?
+		ORDER BY pair, bucket_start
+	`, fromInterval, mark)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type bucketKey struct {
+		pair  string
+		start int64
+	}
+	type aggregate struct {
+		open, high, low, close, volume float64
+	}
+	aggregates := make(map[bucketKey]*aggregate)
+
+	toSeconds := int64(toDuration.Seconds())
+	now := time.Now().Unix()
+	newMark := mark
+
+	for rows.Next() {
+		var pair string
+		var bucketStart int64
+		var open, high, low, close, volume float64
+		if err := rows.Scan(&pair, &bucketStart, &open, &high, &low, &close, &volume); err != nil {
+			return err
+		}
+
+		coarseStart := (bucketStart / toSeconds) * toSeconds
+		if coarseStart+toSeconds > now {
+			continue // coarser bucket hasn't finished yet
+		}
+		if bucketStart > newMark {
+			newMark = bucketStart
+		}
+
+		key := bucketKey{pair, coarseStart}
+		a, ok := aggregates[key]
+		if !ok {
+			aggregates[key] = &aggregate{open: open, high: high, low: low, close: close, volume: volume}
+			continue
+		}
+		if high > a.high {
+			a.high = high
+		}
+		if low < a.low {
+			a.low = low
+		}
+		a.close = close // rows are ordered by bucket_start, so the last write wins
+		a.volume += volume
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for key, a := range aggregates {
+		// open is intentionally left out of the DO UPDATE SET: once a coarse
+		// bucket exists, its open came from the earliest fine bucket folded
+		// into it, which this pass (scanning only newer buckets) never sees
+		// again - overwriting it here would replace the true open with this
+		// batch's.
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO candles (interval, pair, bucket_start, open, high, low, close, volume)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(interval, pair, bucket_start) DO UPDATE SET
+				high = MAX(high, excluded.high),
+				low = MIN(low, excluded.low),
+				close = excluded.close,
+				volume = volume + excluded.volume
+		`, toInterval, key.pair, key.start, a.open, a.high, a.low, a.close, a.volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.rollupMark[fromInterval] = newMark
+	return nil
+}
+
+// pruneTicks deletes raw ticks older than the store's retention window.
+// Candle buckets derived from them are kept indefinitely.
+func (s *SQLiteStore) pruneTicks(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention).Unix()
+	_, err := s.db.ExecContext(ctx, `DELETE FROM ticks WHERE ts < ?`, cutoff)
+	return err
+}
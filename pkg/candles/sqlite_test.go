@@ -0,0 +1,212 @@
+package candles
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewSQLiteStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestFoldTickWidensHighLowAndAccumulatesVolume(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	bucket := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	if err := store.foldTick(ctx, "1m", "BTC/USD", bucket, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.foldTick(ctx, "1m", "BTC/USD", bucket, 90, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.foldTick(ctx, "1m", "BTC/USD", bucket, 110, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Unix(bucket, 0)
+	candles, err := store.Query(ctx, "BTC/USD", "1m", from, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 {
+		t.Fatalf("got %d candles, want 1", len(candles))
+	}
+
+	c := candles[0]
+	if c.Open != 100 {
+		t.Errorf("Open = %v, want 100 (from the first fold)", c.Open)
+	}
+	if c.High != 110 {
+		t.Errorf("High = %v, want 110", c.High)
+	}
+	if c.Low != 90 {
+		t.Errorf("Low = %v, want 90", c.Low)
+	}
+	if c.Close != 110 {
+		t.Errorf("Close = %v, want 110 (the last fold)", c.Close)
+	}
+	if c.Volume != 6 {
+		t.Errorf("Volume = %v, want 6 (accumulated)", c.Volume)
+	}
+}
+
+func TestRollupAggregatesCompletedBuckets(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Five 1m buckets, all inside the same completed 5m window.
+	for i, price := range []float64{100, 105, 95, 102, 98} {
+		bucket := base.Add(time.Duration(i) * time.Minute).Unix()
+		if err := store.foldTick(ctx, "1m", "BTC/USD", bucket, price, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.rollup(ctx, "1m", "5m", 5*time.Minute); err != nil {
+		t.Fatalf("rollup: %v", err)
+	}
+
+	candles, err := store.Query(ctx, "BTC/USD", "5m", base, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 {
+		t.Fatalf("got %d 5m candles, want 1", len(candles))
+	}
+
+	c := candles[0]
+	if c.Open != 100 {
+		t.Errorf("Open = %v, want 100", c.Open)
+	}
+	if c.High != 105 {
+		t.Errorf("High = %v, want 105", c.High)
+	}
+	if c.Low != 95 {
+		t.Errorf("Low = %v, want 95", c.Low)
+	}
+	if c.Close != 98 {
+		t.Errorf("Close = %v, want 98", c.Close)
+	}
+	if c.Volume != 5 {
+		t.Errorf("Volume = %v, want 5", c.Volume)
+	}
+}
+
+func TestRollupSkipsUnfinishedBuckets(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	// A bucket starting now hasn't finished yet, so it must not be rolled up.
+	bucket := time.Now().Truncate(time.Minute).Unix()
+	if err := store.foldTick(ctx, "1m", "ETH/USD", bucket, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.rollup(ctx, "1m", "5m", 5*time.Minute); err != nil {
+		t.Fatalf("rollup: %v", err)
+	}
+
+	candles, err := store.Query(ctx, "ETH/USD", "5m", time.Unix(0, 0), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 0 {
+		t.Errorf("got %d 5m candles for an unfinished bucket, want 0", len(candles))
+	}
+}
+
+func TestRollupHighWaterMarkPreservesEarlierOpenAcrossCalls(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// First two 1m buckets of the 5m window, rolled up in one pass.
+	if err := store.foldTick(ctx, "1m", "BTC/USD", base.Unix(), 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.foldTick(ctx, "1m", "BTC/USD", base.Add(time.Minute).Unix(), 90, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.rollup(ctx, "1m", "5m", 5*time.Minute); err != nil {
+		t.Fatalf("first rollup: %v", err)
+	}
+
+	// Remaining 1m buckets of the same 5m window, rolled up in a second pass -
+	// the high-water mark should mean only these are rescanned, but the
+	// coarse candle's open must still reflect the very first fold.
+	if err := store.foldTick(ctx, "1m", "BTC/USD", base.Add(2*time.Minute).Unix(), 120, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.foldTick(ctx, "1m", "BTC/USD", base.Add(3*time.Minute).Unix(), 80, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.foldTick(ctx, "1m", "BTC/USD", base.Add(4*time.Minute).Unix(), 105, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.rollup(ctx, "1m", "5m", 5*time.Minute); err != nil {
+		t.Fatalf("second rollup: %v", err)
+	}
+
+	candles, err := store.Query(ctx, "BTC/USD", "5m", base, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 {
+		t.Fatalf("got %d 5m candles, want 1", len(candles))
+	}
+
+	c := candles[0]
+	if c.Open != 100 {
+		t.Errorf("Open = %v, want 100 (preserved from the first rollup pass)", c.Open)
+	}
+	if c.High != 120 {
+		t.Errorf("High = %v, want 120", c.High)
+	}
+	if c.Low != 80 {
+		t.Errorf("Low = %v, want 80", c.Low)
+	}
+	if c.Close != 105 {
+		t.Errorf("Close = %v, want 105", c.Close)
+	}
+	if c.Volume != 5 {
+		t.Errorf("Volume = %v, want 5 (accumulated across both passes)", c.Volume)
+	}
+}
+
+func TestPruneTicksDeletesOnlyTicksOlderThanRetention(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	old := time.Now().Add(-2 * time.Hour)
+	recent := time.Now().Add(-time.Minute)
+
+	if err := store.Ingest(ctx, "BTC/USD", 100, 1, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Ingest(ctx, "BTC/USD", 101, 1, recent); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.pruneTicks(ctx); err != nil {
+		t.Fatalf("pruneTicks: %v", err)
+	}
+
+	var count int
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ticks`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d remaining ticks, want 1 (only the recent one)", count)
+	}
+}
@@ -0,0 +1,209 @@
+package exchanges
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MagnetonIO/go-test/pkg/telemetry"
+)
+
+// defaultBreakerThreshold and defaultBreakerCooldown configure the circuit
+// breaker installed for every client registered with NewPriceAggregator.
+const (
+	defaultBreakerThreshold = 3
+	defaultBreakerCooldown  = 2 * time.Minute
+)
+
+// Consensus is the aggregated price for one pair: a volume-weighted average
+// across the healthy exchanges, alongside each exchange's raw last price.
+type Consensus struct {
+	Pair    string
+	VWAP    decimal.Decimal
+	Sources map[string]decimal.Decimal
+	// Volume is the combined volume of the exchanges that contributed to
+	// VWAP, for callers that want to weight or store it (e.g. OHLCV ingest).
+	Volume decimal.Decimal
+}
+
+// PriceAggregator fans out to a set of exchanges and combines their tickers
+// into a single VWAP consensus per pair, skipping exchanges whose circuit
+// breaker is open and discarding statistical outliers before averaging.
+type PriceAggregator struct {
+	clients        []ExchangeClient
+	breakers       map[string]*CircuitBreaker
+	outlierStdDevs float64
+	metrics        *telemetry.Metrics
+}
+
+// NewPriceAggregator builds an aggregator over clients. outlierStdDevs is the
+// number of standard deviations from the median a price may deviate before
+// it's dropped as an outlier; a typical value is 2. metrics records each
+// exchange's attempt outcome and upstream latency.
+func NewPriceAggregator(clients []ExchangeClient, outlierStdDevs float64, metrics *telemetry.Metrics) *PriceAggregator {
+	breakers := make(map[string]*CircuitBreaker, len(clients))
+	for _, c := range clients {
+		breakers[c.Name()] = NewCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+	}
+	return &PriceAggregator{clients: clients, breakers: breakers, outlierStdDevs: outlierStdDevs, metrics: metrics}
+}
+
+// Refresh queries every healthy exchange for pairs in parallel and returns
+// the VWAP consensus for each pair that at least one exchange priced.
+func (a *PriceAggregator) Refresh(ctx context.Context, pairs []string) map[string]Consensus {
+	ctx, span := telemetry.Tracer.Start(ctx, "exchanges.PriceAggregator.Refresh")
+	defer span.End()
+
+	type tickersResult struct {
+		exchange string
+		tickers  map[string]Ticker
+	}
+
+	results := make(chan tickersResult, len(a.clients))
+	var wg sync.WaitGroup
+
+	for _, client := range a.clients {
+		breaker := a.breakers[client.Name()]
+		if !breaker.Allow() {
+			a.metrics.ExchangeAttempts.WithLabelValues(client.Name(), "breaker_open").Inc()
+			continue
+		}
+
+		wg.Add(1)
+		go func(client ExchangeClient, breaker *CircuitBreaker) {
+			defer wg.Done()
+
+			fetchCtx, fetchSpan := telemetry.Tracer.Start(ctx, "exchanges.fetchTickers",
+				trace.WithAttributes(attribute.String("exchange", client.Name())))
+			defer fetchSpan.End()
+
+			start := time.Now()
+			tickers, err := client.FetchTickers(fetchCtx, pairs)
+			a.metrics.UpstreamLatency.WithLabelValues(client.Name()).Observe(time.Since(start).Seconds())
+			if err != nil {
+				breaker.RecordFailure()
+				a.metrics.ExchangeAttempts.WithLabelValues(client.Name(), "error").Inc()
+				fetchSpan.RecordError(err)
+				return
+			}
+			breaker.RecordSuccess()
+			a.metrics.ExchangeAttempts.WithLabelValues(client.Name(), "success").Inc()
+			results <- tickersResult{exchange: client.Name(), tickers: tickers}
+		}(client, breaker)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// byPair collects every exchange's ticker for a given pair so we can
+	// compute the consensus once all venues have reported in.
+	byPair := make(map[string][]Ticker)
+	for r := range results {
+		for pair, ticker := range r.tickers {
+			byPair[pair] = append(byPair[pair], ticker)
+		}
+	}
+
+	consensus := make(map[string]Consensus, len(byPair))
+	for pair, tickers := range byPair {
+		consensus[pair] = a.combine(pair, tickers)
+	}
+	return consensus
+}
+
+// combine computes the VWAP across tickers after dropping outliers more than
+// outlierStdDevs from the median price.
+func (a *PriceAggregator) combine(pair string, tickers []Ticker) Consensus {
+	sources := make(map[string]decimal.Decimal, len(tickers))
+	for _, t := range tickers {
+		sources[t.Exchange] = t.Price
+	}
+
+	healthy := rejectOutliers(tickers, a.outlierStdDevs)
+
+	weightedSum, weightTotal, plainSum := decimal.Zero, decimal.Zero, decimal.Zero
+	for _, t := range healthy {
+		weightedSum = weightedSum.Add(t.Price.Mul(t.Volume))
+		weightTotal = weightTotal.Add(t.Volume)
+		plainSum = plainSum.Add(t.Price)
+	}
+
+	vwap := plainSum.Div(decimal.NewFromInt(int64(len(healthy))))
+	if weightTotal.IsPositive() {
+		vwap = weightedSum.Div(weightTotal)
+	}
+
+	return Consensus{Pair: pair, VWAP: vwap, Sources: sources, Volume: weightTotal}
+}
+
+// rejectOutliers drops tickers whose price is more than stdDevs standard
+// deviations from the median price, guarding against one bad venue skewing
+// the consensus. If filtering would leave nothing, the original set is
+// returned unchanged.
+func rejectOutliers(tickers []Ticker, stdDevs float64) []Ticker {
+	if len(tickers) <= 2 {
+		return tickers
+	}
+
+	prices := make([]decimal.Decimal, len(tickers))
+	for i, t := range tickers {
+		prices[i] = t.Price
+	}
+	median := medianOf(prices)
+	stddev := stddevOf(prices)
+	if stddev.IsZero() {
+		return tickers
+	}
+	threshold := stddev.Mul(decimal.NewFromFloat(stdDevs))
+
+	var kept []Ticker
+	for _, t := range tickers {
+		if t.Price.Sub(median).Abs().LessThanOrEqual(threshold) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == 0 {
+		return tickers
+	}
+	return kept
+}
+
+func medianOf(values []decimal.Decimal) decimal.Decimal {
+	sorted := append([]decimal.Decimal(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+	}
+	return sorted[mid]
+}
+
+// stddevOf computes the population standard deviation. The sum and variance
+// are accumulated exactly in decimal; only the final square root - for
+// which shopspring/decimal has no native operation - goes through float64,
+// and only to size an outlier threshold, not to store or display a price.
+func stddevOf(values []decimal.Decimal) decimal.Decimal {
+	count := decimal.NewFromInt(int64(len(values)))
+	mean := decimal.Zero
+	for _, v := range values {
+		mean = mean.Add(v)
+	}
+	mean = mean.Div(count)
+
+	variance := decimal.Zero
+	for _, v := range values {
+		diff := v.Sub(mean)
+		variance = variance.Add(diff.Mul(diff))
+	}
+	variance = variance.Div(count)
+
+	return decimal.NewFromFloat(math.Sqrt(variance.InexactFloat64()))
+}
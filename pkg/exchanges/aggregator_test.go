@@ -0,0 +1,130 @@
+package exchanges
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func decimals(values ...float64) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		out[i] = decimal.NewFromFloat(v)
+	}
+	return out
+}
+
+func TestMedianOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"odd", []float64{3, 1, 2}, 2},
+		{"even", []float64{1, 2, 3, 4}, 2.5},
+		{"single", []float64{5}, 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			want := decimal.NewFromFloat(tc.want)
+			if got := medianOf(decimals(tc.values...)); !got.Equal(want) {
+				t.Errorf("medianOf(%v) = %v, want %v", tc.values, got, want)
+			}
+		})
+	}
+}
+
+func TestStddevOf(t *testing.T) {
+	if got := stddevOf(decimals(2, 2, 2)); !got.IsZero() {
+		t.Errorf("stddevOf of identical values = %v, want 0", got)
+	}
+
+	got := stddevOf(decimals(2, 4, 4, 4, 5, 5, 7, 9))
+	want := decimal.NewFromFloat(2.0)
+	if !got.Equal(want) {
+		t.Errorf("stddevOf = %v, want %v", got, want)
+	}
+}
+
+func ticker(exchange string, price float64) Ticker {
+	return Ticker{Exchange: exchange, Price: decimal.NewFromFloat(price)}
+}
+
+func TestRejectOutliers(t *testing.T) {
+	tests := []struct {
+		name    string
+		tickers []Ticker
+		want    []string // exchanges expected to survive
+	}{
+		{
+			name: "two or fewer always kept",
+			tickers: []Ticker{
+				ticker("a", 100),
+				ticker("b", 1000),
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "drops a clear outlier",
+			tickers: []Ticker{
+				ticker("a", 100),
+				ticker("b", 101),
+				ticker("c", 99),
+				ticker("d", 10000),
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "identical prices have zero stddev and are all kept",
+			tickers: []Ticker{
+				ticker("a", 100),
+				ticker("b", 100),
+				ticker("c", 100),
+			},
+			want: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kept := rejectOutliers(tc.tickers, 2)
+			if len(kept) != len(tc.want) {
+				t.Fatalf("rejectOutliers kept %d tickers, want %d", len(kept), len(tc.want))
+			}
+			seen := make(map[string]bool, len(kept))
+			for _, tk := range kept {
+				seen[tk.Exchange] = true
+			}
+			for _, exchange := range tc.want {
+				if !seen[exchange] {
+					t.Errorf("expected %s to survive, got %v", exchange, kept)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute)
+
+	if !b.Allow() {
+		t.Fatal("fresh breaker should allow")
+	}
+
+	b.RecordFailure()
+	if !b.Allow() {
+		t.Fatal("breaker should stay closed below threshold")
+	}
+
+	b.RecordFailure()
+	if b.Allow() {
+		t.Fatal("breaker should open once threshold is reached")
+	}
+
+	b.RecordSuccess()
+	if !b.Allow() {
+		t.Fatal("breaker should close again after a recorded success")
+	}
+}
@@ -0,0 +1,93 @@
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// binanceQuoteAliases maps a display quote asset to the quote Binance
+// actually lists spot pairs against, where it differs (Binance has no
+// BTCUSD market, only BTCUSDT).
+var binanceQuoteAliases = map[string]string{
+	"USD": "USDT",
+}
+
+// Binance is an ExchangeClient for https://api.binance.com.
+type Binance struct {
+	client *http.Client
+}
+
+// NewBinance returns a Binance client with sane HTTP timeouts.
+func NewBinance() *Binance {
+	return &Binance{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (b *Binance) Name() string { return "binance" }
+
+// Normalize converts a display pair such as "BTC/USD" into Binance's
+// concatenated symbol form, e.g. "BTCUSDT".
+func (b *Binance) Normalize(pair string) string {
+	base, quote, ok := splitPair(pair)
+	if !ok {
+		return ""
+	}
+	if alt, ok := binanceQuoteAliases[quote]; ok {
+		quote = alt
+	}
+	return base + quote
+}
+
+type binanceTicker struct {
+	Symbol    string `json:"symbol"`
+	LastPrice string `json:"lastPrice"`
+	Volume    string `json:"volume"`
+}
+
+// FetchTickers queries Binance's 24hr ticker endpoint once for all symbols.
+func (b *Binance) FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error) {
+	symbolToPair := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		if sym := b.Normalize(p); sym != "" {
+			symbolToPair[sym] = p
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.binance.com/api/v3/ticker/24hr", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("binance: unexpected status %d", resp.StatusCode)
+	}
+
+	var tickers []binanceTicker
+	if err := json.NewDecoder(resp.Body).Decode(&tickers); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]Ticker, len(pairs))
+	now := time.Now()
+	for _, t := range tickers {
+		pair, ok := symbolToPair[t.Symbol]
+		if !ok {
+			continue
+		}
+		price, err := decimal.NewFromString(t.LastPrice)
+		if err != nil {
+			continue
+		}
+		volume, _ := decimal.NewFromString(t.Volume)
+		out[pair] = Ticker{Pair: pair, Price: price, Volume: volume, Exchange: b.Name(), Time: now}
+	}
+	return out, nil
+}
@@ -0,0 +1,101 @@
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Bitfinex is an ExchangeClient for https://api-pub.bitfinex.com.
+type Bitfinex struct {
+	client *http.Client
+}
+
+// NewBitfinex returns a Bitfinex client with sane HTTP timeouts.
+func NewBitfinex() *Bitfinex {
+	return &Bitfinex{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (bf *Bitfinex) Name() string { return "bitfinex" }
+
+// Normalize converts a display pair such as "BTC/USD" into Bitfinex's
+// "t"-prefixed trading symbol, e.g. "tBTCUSD".
+func (bf *Bitfinex) Normalize(pair string) string {
+	base, quote, ok := splitPair(pair)
+	if !ok {
+		return ""
+	}
+	return "t" + base + quote
+}
+
+// FetchTickers queries Bitfinex's batch tickers endpoint. Each entry in the
+// response is a positional array: [SYMBOL, BID, ..., LAST_PRICE, VOLUME, ...].
+func (bf *Bitfinex) FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error) {
+	symbolToPair := make(map[string]string, len(pairs))
+	symbols := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		sym := bf.Normalize(p)
+		if sym == "" {
+			continue
+		}
+		symbols = append(symbols, sym)
+		symbolToPair[sym] = p
+	}
+
+	url := fmt.Sprintf("https://api-pub.bitfinex.com/v2/tickers?symbols=%s", strings.Join(symbols, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bf.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	// UseNumber keeps LAST_PRICE/VOLUME as their original decimal-string
+	// representation instead of decoding through float64, which would
+	// already have lost precision before we ever get a chance to parse it.
+	dec.UseNumber()
+	var rows [][]interface{}
+	if err := dec.Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]Ticker, len(pairs))
+	now := time.Now()
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		symbol, ok := row[0].(string)
+		if !ok {
+			continue
+		}
+		pair, ok := symbolToPair[symbol]
+		if !ok {
+			continue
+		}
+		priceNum, ok := row[7].(json.Number) // LAST_PRICE
+		if !ok {
+			continue
+		}
+		price, err := decimal.NewFromString(priceNum.String())
+		if err != nil {
+			continue
+		}
+		var volume decimal.Decimal
+		if volNum, ok := row[8].(json.Number); ok { // VOLUME
+			volume, _ = decimal.NewFromString(volNum.String())
+		}
+		out[pair] = Ticker{Pair: pair, Price: price, Volume: volume, Exchange: bf.Name(), Time: now}
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Coinbase is an ExchangeClient for https://api.exchange.coinbase.com.
+type Coinbase struct {
+	client *http.Client
+}
+
+// NewCoinbase returns a Coinbase client with sane HTTP timeouts.
+func NewCoinbase() *Coinbase {
+	return &Coinbase{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (c *Coinbase) Name() string { return "coinbase" }
+
+// Normalize converts a display pair such as "BTC/USD" into Coinbase's
+// dash-separated product id, e.g. "BTC-USD".
+func (c *Coinbase) Normalize(pair string) string {
+	base, quote, ok := splitPair(pair)
+	if !ok {
+		return ""
+	}
+	return base + "-" + quote
+}
+
+type coinbaseTicker struct {
+	Price  string `json:"price"`
+	Volume string `json:"volume"`
+}
+
+// FetchTickers queries Coinbase's per-product ticker endpoint, one request
+// per pair since Coinbase has no batch ticker API.
+func (c *Coinbase) FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error) {
+	out := make(map[string]Ticker, len(pairs))
+	now := time.Now()
+
+	for _, p := range pairs {
+		productID := c.Normalize(p)
+		if productID == "" {
+			continue
+		}
+
+		url := fmt.Sprintf("https://api.exchange.coinbase.com/products/%s/ticker", productID)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			continue // product doesn't exist on Coinbase, skip it
+		}
+
+		var t coinbaseTicker
+		decErr := json.NewDecoder(resp.Body).Decode(&t)
+		resp.Body.Close()
+		if decErr != nil {
+			continue
+		}
+
+		price, err := decimal.NewFromString(t.Price)
+		if err != nil {
+			continue
+		}
+		volume, _ := decimal.NewFromString(t.Volume)
+		out[p] = Ticker{Pair: p, Price: price, Volume: volume, Exchange: c.Name(), Time: now}
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+// Package exchanges provides a common abstraction over the venues this
+// service pulls ticker data from, so the price cache can fan out to all of
+// them instead of hard-coding Kraken.
+package exchanges
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Ticker is a single last-traded-price observation from one exchange. Price
+// and Volume are decimal, not float64, so a venue's quote keeps its exact
+// precision all the way through aggregation instead of losing sub-cent
+// digits to float64 before it ever reaches roundToTick.
+type Ticker struct {
+	Pair     string
+	Price    decimal.Decimal
+	Volume   decimal.Decimal
+	Exchange string
+	Time     time.Time
+}
+
+// ExchangeClient fetches ticker data for a set of trading pairs from a single venue.
+type ExchangeClient interface {
+	// Name returns the exchange's identifier, e.g. "kraken".
+	Name() string
+	// Normalize maps a display pair (e.g. "BTC/USD") to this exchange's own
+	// symbol, handling quirks like Kraken's XBT/X/Z prefixing.
+	Normalize(pair string) string
+	// FetchTickers retrieves the latest ticker for each requested pair.
+	// Pairs the exchange doesn't support or couldn't price are simply
+	// omitted from the result rather than returned as an error.
+	FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error)
+}
+
+// CircuitBreaker tracks consecutive failures for one exchange and trips open
+// for a cooldown window so a flaky venue is skipped instead of dragging
+// every refresh through its full retry ladder.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker returns a breaker that opens after threshold consecutive
+// failures and stays open for cooldown before allowing another attempt.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a request should be attempted right now.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+// RecordSuccess resets the failure count, closing the breaker.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+// RecordFailure registers a failed attempt, tripping the breaker open once
+// the threshold is reached.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
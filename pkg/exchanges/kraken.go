@@ -0,0 +1,198 @@
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// krakenBases maps a display base asset to Kraken's own asset code, where it
+// differs (most notably BTC -> XBT).
+var krakenBases = map[string]string{
+	"BTC": "XBT",
+}
+
+// Kraken is an ExchangeClient for https://api.kraken.com.
+type Kraken struct {
+	client *http.Client
+}
+
+// NewKraken returns a Kraken client with sane HTTP timeouts.
+func NewKraken() *Kraken {
+	return &Kraken{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (k *Kraken) Name() string { return "kraken" }
+
+// Normalize converts a display pair such as "BTC/USD" into Kraken's
+// concatenated symbol form, e.g. "XBTUSD".
+func (k *Kraken) Normalize(pair string) string {
+	base, quote, ok := splitPair(pair)
+	if !ok {
+		return ""
+	}
+	return k.BaseAlias(base) + quote
+}
+
+// BaseAlias returns the asset code Kraken uses for a display base asset,
+// where it differs (most notably BTC -> XBT).
+func (k *Kraken) BaseAlias(base string) string {
+	if alt, ok := krakenBases[base]; ok {
+		return alt
+	}
+	return base
+}
+
+type krakenTickerResponse struct {
+	Error  []string                    `json:"error"`
+	Result map[string]krakenTickerPair `json:"result"`
+}
+
+type krakenTickerPair struct {
+	C []string `json:"c"` // last trade closed: [price, lot volume]
+	V []string `json:"v"` // volume: [today, last 24h]
+}
+
+// FetchTickers queries Kraken's public Ticker endpoint for all requested
+// pairs in a single combined request.
+func (k *Kraken) FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error) {
+	symbols := make([]string, 0, len(pairs))
+	symbolToPair := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		sym := k.Normalize(p)
+		if sym == "" {
+			continue
+		}
+		symbols = append(symbols, sym)
+		symbolToPair[sym] = p
+		// Kraken echoes some pairs back with X/Z asset-class prefixes
+		// (e.g. XXBTZUSD); register that form too so lookups succeed.
+		symbolToPair["X"+sym[:3]+"Z"+sym[3:]] = p
+	}
+
+	url := fmt.Sprintf("https://api.kraken.com/0/public/Ticker?pair=%s", strings.Join(symbols, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed krakenTickerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Error) > 0 {
+		return nil, fmt.Errorf("kraken: %v", parsed.Error)
+	}
+
+	out := make(map[string]Ticker, len(pairs))
+	now := time.Now()
+	for symbol, ticker := range parsed.Result {
+		pair, ok := symbolToPair[symbol]
+		if !ok || len(ticker.C) < 1 {
+			continue
+		}
+		price, err := decimal.NewFromString(ticker.C[0])
+		if err != nil {
+			continue
+		}
+		var volume decimal.Decimal
+		if len(ticker.V) >= 2 {
+			volume, _ = decimal.NewFromString(ticker.V[1])
+		}
+		out[pair] = Ticker{Pair: pair, Price: price, Volume: volume, Exchange: k.Name(), Time: now}
+	}
+	return out, nil
+}
+
+// AssetPairInfo carries the minimum tradeable increments Kraken enforces
+// for a pair, derived from its decimal precision.
+type AssetPairInfo struct {
+	PriceTickSize  decimal.Decimal
+	AmountTickSize decimal.Decimal
+}
+
+type krakenAssetPairsResponse struct {
+	Error  []string                        `json:"error"`
+	Result map[string]krakenAssetPairEntry `json:"result"`
+}
+
+type krakenAssetPairEntry struct {
+	PairDecimals int `json:"pair_decimals"`
+	LotDecimals  int `json:"lot_decimals"`
+}
+
+// FetchAssetPairs queries Kraken's AssetPairs endpoint and returns the
+// price and amount tick sizes for each requested display pair, keyed by
+// that same display pair (e.g. "BTC/USD").
+func (k *Kraken) FetchAssetPairs(ctx context.Context, pairs []string) (map[string]AssetPairInfo, error) {
+	symbolToPair := make(map[string]string, len(pairs))
+	symbols := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		sym := k.Normalize(p)
+		if sym == "" {
+			continue
+		}
+		symbols = append(symbols, sym)
+		symbolToPair[sym] = p
+		symbolToPair["X"+sym[:3]+"Z"+sym[3:]] = p
+	}
+
+	url := fmt.Sprintf("https://api.kraken.com/0/public/AssetPairs?pair=%s", strings.Join(symbols, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed krakenAssetPairsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Error) > 0 {
+		return nil, fmt.Errorf("kraken: %v", parsed.Error)
+	}
+
+	out := make(map[string]AssetPairInfo, len(pairs))
+	for symbol, entry := range parsed.Result {
+		pair, ok := symbolToPair[symbol]
+		if !ok {
+			continue
+		}
+		out[pair] = AssetPairInfo{
+			PriceTickSize:  tickSizeFromDecimals(entry.PairDecimals),
+			AmountTickSize: tickSizeFromDecimals(entry.LotDecimals),
+		}
+	}
+	return out, nil
+}
+
+// tickSizeFromDecimals converts Kraken's "N decimal places" precision into
+// the minimum increment it represents, e.g. 2 -> 0.01.
+func tickSizeFromDecimals(decimals int) decimal.Decimal {
+	return decimal.New(1, int32(-decimals))
+}
+
+// splitPair breaks a display pair like "BTC/USD" into its base and quote.
+func splitPair(pair string) (base, quote string, ok bool) {
+	parts := strings.SplitN(pair, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,80 @@
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Kucoin is an ExchangeClient for https://api.kucoin.com.
+type Kucoin struct {
+	client *http.Client
+}
+
+// NewKucoin returns a Kucoin client with sane HTTP timeouts.
+func NewKucoin() *Kucoin {
+	return &Kucoin{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (k *Kucoin) Name() string { return "kucoin" }
+
+// Normalize converts a display pair such as "BTC/USD" into Kucoin's
+// dash-separated symbol, e.g. "BTC-USD".
+func (k *Kucoin) Normalize(pair string) string {
+	base, quote, ok := splitPair(pair)
+	if !ok {
+		return ""
+	}
+	return base + "-" + quote
+}
+
+type kucoinLevel1Response struct {
+	Code string `json:"code"`
+	Data struct {
+		Price string `json:"price"`
+		Size  string `json:"size"`
+	} `json:"data"`
+}
+
+// FetchTickers queries Kucoin's level1 orderbook endpoint, one request per
+// pair since the public REST API has no multi-symbol ticker call.
+func (k *Kucoin) FetchTickers(ctx context.Context, pairs []string) (map[string]Ticker, error) {
+	out := make(map[string]Ticker, len(pairs))
+	now := time.Now()
+
+	for _, p := range pairs {
+		symbol := k.Normalize(p)
+		if symbol == "" {
+			continue
+		}
+
+		url := fmt.Sprintf("https://api.kucoin.com/api/v1/market/orderbook/level1?symbol=%s", symbol)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := k.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var parsed kucoinLevel1Response
+		decErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if decErr != nil || parsed.Code != "200000" || parsed.Data.Price == "" {
+			continue
+		}
+
+		price, err := decimal.NewFromString(parsed.Data.Price)
+		if err != nil {
+			continue
+		}
+		volume, _ := decimal.NewFromString(parsed.Data.Size)
+		out[p] = Ticker{Pair: p, Price: price, Volume: volume, Exchange: k.Name(), Time: now}
+	}
+	return out, nil
+}
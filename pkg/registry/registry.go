@@ -0,0 +1,190 @@
+// Package registry turns the set of supported trading pairs from a
+// hard-coded map into a thread-safe, runtime-mutable registry seeded from a
+// config file and validated against Kraken on every addition.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	"github.com/MagnetonIO/go-test/pkg/exchanges"
+)
+
+// Pair represents a trading pair.
+type Pair struct {
+	Base    string `json:"base" yaml:"base"`
+	Quote   string `json:"quote" yaml:"quote"`
+	Display string `json:"display" yaml:"display"`
+	// PriceTickSize and AmountTickSize are the minimum increments Kraken
+	// allows for this pair's price and order size. Zero until resolved,
+	// in which case amounts are left unrounded.
+	// decimal.Decimal is a struct, so encoding/json's omitempty never
+	// treats it as empty - these always marshal, as "0" before resolution.
+	PriceTickSize  decimal.Decimal `json:"price_tick_size" yaml:"-"`
+	AmountTickSize decimal.Decimal `json:"amount_tick_size" yaml:"-"`
+}
+
+type configFile struct {
+	Pairs []Pair `yaml:"pairs"`
+}
+
+// Registry is a thread-safe set of supported pairs. New pairs are validated
+// against Kraken's AssetPairs before being admitted.
+type Registry struct {
+	mu     sync.RWMutex
+	pairs  map[string]Pair
+	kraken *exchanges.Kraken
+	onAdd  func(Pair)
+}
+
+// NewRegistry returns an empty registry. Call LoadFile to seed it.
+func NewRegistry(kraken *exchanges.Kraken) *Registry {
+	return &Registry{pairs: make(map[string]Pair), kraken: kraken}
+}
+
+// OnAdd registers a hook invoked after a pair is successfully added, so
+// callers can wire in e.g. an immediate cache refresh or a websocket
+// subscription for just that pair.
+func (r *Registry) OnAdd(fn func(Pair)) {
+	r.onAdd = fn
+}
+
+// LoadFile seeds the registry from a YAML config shaped like:
+//
+//	pairs:
+//	  - base: XBT
+//	    quote: USD
+//	    display: BTC/USD
+func (r *Registry) LoadFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read pair config: %w", err)
+	}
+
+	var cfg configFile
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return fmt.Errorf("parse pair config: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range cfg.Pairs {
+		if p.Display == "" {
+			p.Display = p.Base + "/" + p.Quote
+		}
+		r.pairs[strings.ToUpper(p.Display)] = p
+	}
+	return nil
+}
+
+// All returns every registered pair.
+func (r *Registry) All() []Pair {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Pair, 0, len(r.pairs))
+	for _, p := range r.pairs {
+		out = append(out, p)
+	}
+	return out
+}
+
+// Get looks up a pair by its display name (case-insensitive).
+func (r *Registry) Get(display string) (Pair, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.pairs[strings.ToUpper(display)]
+	return p, ok
+}
+
+// RefreshTickSizes re-fetches price/amount tick sizes for every registered
+// pair from Kraken's AssetPairs endpoint.
+func (r *Registry) RefreshTickSizes(ctx context.Context) error {
+	r.mu.Lock()
+	displays := make([]string, 0, len(r.pairs))
+	for display := range r.pairs {
+		displays = append(displays, display)
+	}
+	r.mu.Unlock()
+
+	info, err := r.kraken.FetchAssetPairs(ctx, displays)
+	if err != nil {
+		return fmt.Errorf("fetch tick sizes: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for display, p := range r.pairs {
+		if i, ok := info[display]; ok {
+			p.PriceTickSize = i.PriceTickSize
+			p.AmountTickSize = i.AmountTickSize
+			r.pairs[display] = p
+		}
+	}
+	return nil
+}
+
+// Add validates display (e.g. "ETH/USD") against Kraken's AssetPairs,
+// resolves its base asset code and tick sizes, and admits it to the
+// registry. It fires the OnAdd hook on success.
+func (r *Registry) Add(ctx context.Context, display string) (Pair, error) {
+	display = strings.ToUpper(display)
+	base, quote, ok := splitPair(display)
+	if !ok {
+		return Pair{}, fmt.Errorf("invalid pair %q, expected BASE/QUOTE", display)
+	}
+
+	info, err := r.kraken.FetchAssetPairs(ctx, []string{display})
+	if err != nil {
+		return Pair{}, fmt.Errorf("validate %s against kraken: %w", display, err)
+	}
+	tickInfo, ok := info[display]
+	if !ok {
+		return Pair{}, fmt.Errorf("kraken does not list pair %s", display)
+	}
+
+	pair := Pair{
+		Base:           r.kraken.BaseAlias(base),
+		Quote:          quote,
+		Display:        display,
+		PriceTickSize:  tickInfo.PriceTickSize,
+		AmountTickSize: tickInfo.AmountTickSize,
+	}
+
+	r.mu.Lock()
+	r.pairs[display] = pair
+	r.mu.Unlock()
+
+	if r.onAdd != nil {
+		r.onAdd(pair)
+	}
+	return pair, nil
+}
+
+// Remove drops a pair from the registry, returning it and whether it was
+// present.
+func (r *Registry) Remove(display string) (Pair, bool) {
+	display = strings.ToUpper(display)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.pairs[display]
+	if !ok {
+		return Pair{}, false
+	}
+	delete(r.pairs, display)
+	return p, true
+}
+
+// splitPair breaks a display pair like "BTC/USD" into its base and quote.
+func splitPair(pair string) (base, quote string, ok bool) {
+	parts := strings.SplitN(pair, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
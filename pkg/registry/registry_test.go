@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MagnetonIO/go-test/pkg/exchanges"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pairs.yaml")
+	contents := `
+pairs:
+  - base: XBT
+    quote: USD
+    display: BTC/USD
+  - base: ETH
+    quote: USD
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(exchanges.NewKraken())
+	if err := r.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	if _, ok := r.Get("BTC/USD"); !ok {
+		t.Error("expected BTC/USD to be loaded")
+	}
+
+	// display defaults to base/quote when omitted from the config.
+	p, ok := r.Get("ETH/USD")
+	if !ok {
+		t.Fatal("expected ETH/USD to be loaded with a derived display")
+	}
+	if p.Display != "ETH/USD" {
+		t.Errorf("derived display = %q, want ETH/USD", p.Display)
+	}
+
+	if len(r.All()) != 2 {
+		t.Errorf("All() returned %d pairs, want 2", len(r.All()))
+	}
+}
+
+func TestLoadFileMissingPath(t *testing.T) {
+	r := NewRegistry(exchanges.NewKraken())
+	if err := r.LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error loading a nonexistent config file")
+	}
+}
+
+func TestGetIsCaseInsensitive(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pairs.yaml")
+	if err := os.WriteFile(path, []byte("pairs:\n  - base: XBT\n    quote: USD\n    display: BTC/USD\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(exchanges.NewKraken())
+	if err := r.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := r.Get("btc/usd"); !ok {
+		t.Error("expected Get to be case-insensitive")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pairs.yaml")
+	if err := os.WriteFile(path, []byte("pairs:\n  - base: XBT\n    quote: USD\n    display: BTC/USD\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(exchanges.NewKraken())
+	if err := r.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	p, ok := r.Remove("BTC/USD")
+	if !ok {
+		t.Fatal("expected BTC/USD to be removed")
+	}
+	if p.Display != "BTC/USD" {
+		t.Errorf("removed pair = %+v, want Display BTC/USD", p)
+	}
+
+	if _, ok := r.Get("BTC/USD"); ok {
+		t.Error("expected BTC/USD to no longer be registered")
+	}
+
+	if _, ok := r.Remove("BTC/USD"); ok {
+		t.Error("expected removing an already-removed pair to report not found")
+	}
+}
+
+func TestAddRejectsMalformedPair(t *testing.T) {
+	r := NewRegistry(exchanges.NewKraken())
+	if _, err := r.Add(context.Background(), "NOTAPAIR"); err == nil {
+		t.Error("expected Add to reject a pair without a BASE/QUOTE separator")
+	}
+}
@@ -0,0 +1,284 @@
+// Package stream maintains a live websocket subscription to Kraken's public
+// ticker feed so the price cache can be driven by pushed ticks instead of
+// polling REST on a fixed interval.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const krakenWSURL = "wss://ws.kraken.com"
+
+// pingInterval and pongWait implement the keepalive half of the connection:
+// every pingInterval we send a control-frame ping, and pongWait (comfortably
+// longer than pingInterval) is how long we'll wait for Kraken to answer - via
+// either a pong frame or any other traffic - before treating the connection
+// as dead and letting the read loop error out into Run's reconnect logic.
+const (
+	pingInterval = 15 * time.Second
+	pongWait     = 20 * time.Second
+)
+
+// Update is a single tick pushed from the feed for one pair.
+type Update struct {
+	Pair   string // display form, e.g. "BTC/USD"
+	Price  float64
+	Volume float64
+	Time   time.Time
+}
+
+// Handler is invoked for every tick the stream receives.
+type Handler func(Update)
+
+// KrakenStream subscribes to Kraken's ticker channel for a set of pairs -
+// which can grow or shrink at runtime via AddPair/RemovePair - and
+// reconnects with exponential backoff and jitter if the connection drops or
+// the handshake fails.
+type KrakenStream struct {
+	onUpdate Handler
+	logger   *zap.Logger
+
+	mu           sync.RWMutex
+	wsNameToPair map[string]string // Kraken's "XBT/USD" form -> display "BTC/USD"
+	lastSeen     map[string]time.Time
+	conn         *websocket.Conn // non-nil while a connection is live
+
+	// writeMu serializes every data-frame write (WriteJSON) against conn:
+	// gorilla/websocket allows only one concurrent writer, and AddPair,
+	// RemovePair, and connectAndListen's initial subscribe can otherwise
+	// all reach the same conn at once. WriteControl/Close are documented
+	// safe without it, so the ping goroutine doesn't take this lock.
+	writeMu sync.Mutex
+}
+
+// NewKrakenStream builds a stream over pairs, a map of display pair name to
+// Kraken's own slash-separated wsname (e.g. "BTC/USD" -> "XBT/USD"). logger
+// is used for reconnect/backoff diagnostics so they carry the service's
+// structured fields instead of going to the standard log package.
+func NewKrakenStream(pairs map[string]string, onUpdate Handler, logger *zap.Logger) *KrakenStream {
+	wsNameToPair := make(map[string]string, len(pairs))
+	for display, wsName := range pairs {
+		wsNameToPair[wsName] = display
+	}
+	return &KrakenStream{
+		wsNameToPair: wsNameToPair,
+		onUpdate:     onUpdate,
+		logger:       logger,
+		lastSeen:     make(map[string]time.Time),
+	}
+}
+
+// Run connects and listens until ctx is cancelled, reconnecting with
+// exponential backoff and jitter whenever the connection is lost.
+func (s *KrakenStream) Run(ctx context.Context) {
+	attempt := 0
+	for ctx.Err() == nil {
+		if err := s.connectAndListen(ctx); err != nil {
+			s.logger.Error("kraken stream disconnected", zap.Error(err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		backoff := time.Duration(100*(1<<attempt)) * time.Millisecond
+		// Past ~37 attempts the shift overflows to zero or a negative value.
+		if backoff <= 0 || backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+		jitter := time.Duration(rand.Intn(500)) * time.Millisecond
+		attempt++
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff + jitter):
+		}
+	}
+}
+
+// LastUpdated reports when a pair last received a tick over the stream.
+func (s *KrakenStream) LastUpdated(pair string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.lastSeen[pair]
+	return t, ok
+}
+
+// AddPair registers a new pair for the stream to track. If a connection is
+// currently live, it's subscribed to immediately; otherwise it's picked up
+// on the next connect.
+func (s *KrakenStream) AddPair(display, wsName string) error {
+	s.mu.Lock()
+	s.wsNameToPair[wsName] = display
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteJSON(subscriptionMessage("subscribe", []string{wsName}))
+}
+
+// RemovePair stops tracking a pair, unsubscribing from the live connection
+// if there is one.
+func (s *KrakenStream) RemovePair(display, wsName string) error {
+	s.mu.Lock()
+	delete(s.wsNameToPair, wsName)
+	delete(s.lastSeen, display)
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteJSON(subscriptionMessage("unsubscribe", []string{wsName}))
+}
+
+func subscriptionMessage(event string, wsNames []string) map[string]interface{} {
+	return map[string]interface{}{
+		"event": event,
+		"pair":  wsNames,
+		"subscription": map[string]string{
+			"name": "ticker",
+		},
+	}
+}
+
+func (s *KrakenStream) connectAndListen(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, krakenWSURL, nil)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	s.mu.Lock()
+	wsNames := make([]string, 0, len(s.wsNameToPair))
+	for wsName := range s.wsNameToPair {
+		wsNames = append(wsNames, wsName)
+	}
+	s.conn = conn
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+	}()
+
+	if len(wsNames) > 0 {
+		s.writeMu.Lock()
+		err := conn.WriteJSON(subscriptionMessage("subscribe", wsNames))
+		s.writeMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("subscribe: %w", err)
+		}
+	}
+
+	// A pong - or any other read - pushes the deadline out; if Kraken goes
+	// quiet for longer than pongWait, ReadMessage below starts failing and
+	// Run reconnects.
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	pingDone := make(chan struct{})
+	defer close(pingDone)
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-pingDone:
+				return
+			case <-ticker.C:
+				// WriteControl is safe to call concurrently with the
+				// writeMu-guarded WriteJSON calls elsewhere on this conn,
+				// so it doesn't need to take writeMu itself.
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		s.handleMessage(raw)
+	}
+}
+
+// handleMessage dispatches an incoming frame. Event frames (heartbeat,
+// subscriptionStatus, ...) arrive as JSON objects; ticker ticks arrive as
+// a 4-element JSON array: [channelID, tickerPayload, "ticker", wsName].
+func (s *KrakenStream) handleMessage(raw []byte) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "{") {
+		// Event message (heartbeat, systemStatus, subscriptionStatus) -
+		// nothing to update the cache with.
+		return
+	}
+
+	var frame []json.RawMessage
+	if err := json.Unmarshal(raw, &frame); err != nil || len(frame) < 4 {
+		return
+	}
+
+	var channelType string
+	if err := json.Unmarshal(frame[2], &channelType); err != nil || channelType != "ticker" {
+		return
+	}
+
+	var wsName string
+	if err := json.Unmarshal(frame[3], &wsName); err != nil {
+		return
+	}
+	s.mu.RLock()
+	pair, ok := s.wsNameToPair[wsName]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		C []string `json:"c"` // last trade closed: [price, lot volume]
+		V []string `json:"v"` // volume: [today, last 24h]
+	}
+	if err := json.Unmarshal(frame[1], &payload); err != nil || len(payload.C) < 1 {
+		return
+	}
+
+	var price, volume float64
+	fmt.Sscanf(payload.C[0], "%f", &price)
+	if len(payload.V) >= 1 {
+		fmt.Sscanf(payload.V[0], "%f", &volume)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.lastSeen[pair] = now
+	s.mu.Unlock()
+
+	s.onUpdate(Update{Pair: pair, Price: price, Volume: volume, Time: now})
+}
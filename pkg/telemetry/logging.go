@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type requestIDKey struct{}
+
+// RequestIDHeader is the header clients may set - and that the service
+// always echoes back - to correlate a request across logs and traces.
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestID returns a context carrying id for later retrieval by
+// RequestIDFromContext.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stashed by WithRequestID, or
+// "" if the context carries none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewLogger returns the service's structured JSON logger.
+func NewLogger() *zap.Logger {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+	return logger
+}
+
+// WithRequest returns a child logger annotated with ctx's correlation ID, so
+// every log line a request touches can be grepped out of the rest.
+func WithRequest(logger *zap.Logger, ctx context.Context) *zap.Logger {
+	return logger.With(zap.String("request_id", RequestIDFromContext(ctx)))
+}
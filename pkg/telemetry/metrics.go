@@ -0,0 +1,62 @@
+// Package telemetry wires together the service's observability surface:
+// Prometheus metrics, structured JSON logging with per-request correlation,
+// and OpenTelemetry tracing for the paths that matter most when debugging a
+// bad price - the exchange fetch loop, the per-pair fallback path, and cache
+// lock contention.
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds every Prometheus collector the service exports on /metrics.
+type Metrics struct {
+	RequestsPerPair  *prometheus.CounterVec
+	CacheHits        prometheus.Counter
+	CacheMisses      prometheus.Counter
+	CacheStale       prometheus.Counter
+	ExchangeAttempts *prometheus.CounterVec
+	HTTPResponses    *prometheus.CounterVec
+	UpstreamLatency  *prometheus.HistogramVec
+	HandlerLatency   *prometheus.HistogramVec
+}
+
+// NewMetrics registers every collector against the default Prometheus
+// registry and returns the handle used to record them.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		RequestsPerPair: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "go_test_ltp_requests_total",
+			Help: "LTP requests served, by pair.",
+		}, []string{"pair"}),
+		CacheHits: promauto.NewCounter(prometheus.CounterOpts{
+			Name: "go_test_cache_hits_total",
+			Help: "LTP requests for a pair served from the in-memory cache.",
+		}),
+		CacheMisses: promauto.NewCounter(prometheus.CounterOpts{
+			Name: "go_test_cache_misses_total",
+			Help: "LTP requests for a pair with no cached price yet.",
+		}),
+		CacheStale: promauto.NewCounter(prometheus.CounterOpts{
+			Name: "go_test_cache_stale_total",
+			Help: "LTP requests that found at least one requested pair stale.",
+		}),
+		ExchangeAttempts: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "go_test_exchange_attempts_total",
+			Help: "Exchange fetch attempts during price reconciliation, by exchange and outcome.",
+		}, []string{"exchange", "outcome"}),
+		HTTPResponses: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "go_test_http_responses_total",
+			Help: "HTTP responses served, by route and status code.",
+		}, []string{"route", "status"}),
+		UpstreamLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "go_test_upstream_latency_seconds",
+			Help: "Latency of a single exchange's ticker fetch during reconciliation.",
+		}, []string{"exchange"}),
+		HandlerLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "go_test_handler_latency_seconds",
+			Help: "End-to-end HTTP handler latency, by route.",
+		}, []string{"route"}),
+	}
+}
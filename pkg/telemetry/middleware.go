@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDMiddleware assigns every request a correlation ID - reusing one
+// supplied via RequestIDHeader, generating a fresh one otherwise - stashes
+// it on the request context, and echoes it back on the response so a client
+// and the service logs agree on what to call the request.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// statusRecorder captures the status code a handler wrote, since
+// http.ResponseWriter doesn't expose it after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying ResponseWriter so http.NewResponseController
+// can see past this wrapper to optional interfaces (Flusher, Hijacker, ...)
+// the embedded http.ResponseWriter interface doesn't promote on its own.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Instrument wraps a handler registered under route, recording its latency
+// and the distribution of status codes it returns. Handlers that never call
+// WriteHeader explicitly are counted as 200, matching net/http's own
+// default.
+func Instrument(m *Metrics, route string, h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		h(rec, r)
+		m.HandlerLatency.WithLabelValues(route).Observe(time.Since(start).Seconds())
+		m.HTTPResponses.WithLabelValues(route, strconv.Itoa(rec.status)).Inc()
+	})
+}
@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Tracer is the service-wide tracer. It instruments the exchange fetch loop,
+// the per-pair reconciliation fallback, and cache lock contention - the
+// paths an operator actually needs spans for when a price looks wrong or
+// stale. Until InitTracing registers a real provider, it runs against
+// OpenTelemetry's no-op default and records nothing.
+var Tracer = otel.Tracer("go-test")
+
+// InitTracing registers an SDK TracerProvider as the OpenTelemetry global so
+// every Tracer.Start call actually records and exports a span. Spans are
+// written to stdout; swap in an OTLP exporter here once a collector is
+// available. Callers should invoke the returned shutdown func before the
+// process exits to flush any spans still buffered.
+func InitTracing(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceNameKey.String("go-test")))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import "sync"
+
+// sseHub fans out price ticks to every connected /api/v1/stream client.
+type sseHub struct {
+	mu      sync.Mutex
+	clients map[chan LTPData]struct{}
+}
+
+func newSSEHub() *sseHub {
+	return &sseHub{clients: make(map[chan LTPData]struct{})}
+}
+
+// subscribe registers a new client and returns the channel it should read
+// ticks from.
+func (h *sseHub) subscribe() chan LTPData {
+	ch := make(chan LTPData, 16)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+// unsubscribe removes a client and closes its channel.
+func (h *sseHub) unsubscribe(ch chan LTPData) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	close(ch)
+	h.mu.Unlock()
+}
+
+// publish delivers a tick to every subscriber, dropping it for clients that
+// are reading too slowly rather than blocking the feed for everyone else.
+func (h *sseHub) publish(data LTPData) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}